@@ -0,0 +1,29 @@
+package report
+
+import "github.com/GoogleCloudPlatform/magic-modules/tools/breaking-change-detector/rules"
+
+// NewFinding builds a Finding from a single rule violation, pulling the
+// rule's Identifier/Name/Definition/Message so callers don't have to
+// know those mappings themselves.
+func NewFinding(rule rules.ResourceSchemaRule, version, resource, field string) Finding {
+	return Finding{
+		RuleIdentifier: rule.Identifier(),
+		RuleName:       rule.Name(),
+		HelpText:       rule.Definition(),
+		Resource:       resource,
+		Field:          field,
+		Message:        rule.Message(version, resource, field),
+	}
+}
+
+// NewFindings builds one Finding per field returned by
+// ResourceSchemaRule.IsRuleBreak, e.g.:
+//
+//	report.NewFindings(rule, version, resourceName, rule.IsRuleBreak(old, new))
+func NewFindings(rule rules.ResourceSchemaRule, version, resource string, fields []string) []Finding {
+	findings := make([]Finding, 0, len(fields))
+	for _, field := range fields {
+		findings = append(findings, NewFinding(rule, version, resource, field))
+	}
+	return findings
+}
@@ -0,0 +1,118 @@
+// Package report turns rule violations into formats CI systems can
+// consume: SARIF 2.1.0 for GitHub code scanning, and a waiver mechanism
+// for rules a team has consciously decided to accept. Results carry
+// logical locations (resource/field), not physical file/line positions,
+// since a schema diff isn't tied to a line in the generated provider
+// source; consumers that need inline PR annotations (e.g. Reviewdog)
+// should map RuleIdentifier/Resource/Field to a source location themselves.
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Finding is one (resource, field, rule) violation, ready to be reported
+// or checked against a waiver.
+type Finding struct {
+	RuleIdentifier string
+	RuleName       string
+	HelpText       string
+	Resource       string
+	Field          string
+	Message        string
+}
+
+const sarifSchema = "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json"
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID   string    `json:"id"`
+	Name string    `json:"name"`
+	Help sarifHelp `json:"help"`
+}
+
+type sarifHelp struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+// MarshalSARIF renders findings as a SARIF 2.1.0 log with one result per
+// (resource, field, rule) tuple. Each distinct rule is declared once on
+// the driver, with the rule's Definition surfaced as help.text.
+func MarshalSARIF(findings []Finding) ([]byte, error) {
+	driver := sarifDriver{Name: "breaking-change-detector"}
+	declaredRules := map[string]bool{}
+	results := make([]sarifResult, 0, len(findings))
+
+	for _, finding := range findings {
+		if !declaredRules[finding.RuleIdentifier] {
+			declaredRules[finding.RuleIdentifier] = true
+			driver.Rules = append(driver.Rules, sarifRule{
+				ID:   finding.RuleIdentifier,
+				Name: finding.RuleName,
+				Help: sarifHelp{Text: finding.HelpText},
+			})
+		}
+
+		results = append(results, sarifResult{
+			RuleID:  finding.RuleIdentifier,
+			Level:   "error",
+			Message: sarifMessage{Text: finding.Message},
+			Locations: []sarifLocation{{
+				LogicalLocations: []sarifLogicalLocation{{
+					FullyQualifiedName: fmt.Sprintf("%s.%s", finding.Resource, finding.Field),
+					Kind:               "resource",
+				}},
+			}},
+		})
+	}
+
+	log := sarifLog{
+		Schema:  sarifSchema,
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool:    sarifTool{Driver: driver},
+			Results: results,
+		}},
+	}
+	return json.MarshalIndent(log, "", "  ")
+}
@@ -0,0 +1,86 @@
+package report
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMarshalSARIF(t *testing.T) {
+	findings := []Finding{
+		{
+			RuleIdentifier: "resource-schema-field-force-new",
+			RuleName:       "Adding ForceNew to a field",
+			HelpText:       "Marking a previously updatable field as ForceNew...",
+			Resource:       "google_compute_instance",
+			Field:          "machine_type",
+			Message:        "Field `machine_type` within resource `google_compute_instance` gained ForceNew",
+		},
+		{
+			RuleIdentifier: "resource-schema-field-force-new",
+			RuleName:       "Adding ForceNew to a field",
+			HelpText:       "Marking a previously updatable field as ForceNew...",
+			Resource:       "google_compute_instance",
+			Field:          "zone",
+			Message:        "Field `zone` within resource `google_compute_instance` gained ForceNew",
+		},
+		{
+			RuleIdentifier: "resource-schema-field-sensitive-removed",
+			RuleName:       "Removing Sensitive from a field",
+			HelpText:       "A field marked Sensitive...",
+			Resource:       "google_sql_user",
+			Field:          "password",
+			Message:        "Field `password` within resource `google_sql_user` stopped being Sensitive",
+		},
+	}
+
+	data, err := MarshalSARIF(findings)
+	if err != nil {
+		t.Fatalf("MarshalSARIF returned an error: %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(data, &log); err != nil {
+		t.Fatalf("MarshalSARIF produced invalid JSON: %v", err)
+	}
+
+	if log.Version != "2.1.0" {
+		t.Fatalf("got version %q, want 2.1.0", log.Version)
+	}
+	if len(log.Runs) != 1 {
+		t.Fatalf("got %d runs, want 1", len(log.Runs))
+	}
+
+	driver := log.Runs[0].Tool.Driver
+	if len(driver.Rules) != 2 {
+		t.Fatalf("got %d declared rules, want 2 (one per distinct rule identifier), got %+v", len(driver.Rules), driver.Rules)
+	}
+
+	results := log.Runs[0].Results
+	if len(results) != len(findings) {
+		t.Fatalf("got %d results, want %d", len(results), len(findings))
+	}
+	if got, want := results[0].Locations[0].LogicalLocations[0].FullyQualifiedName, "google_compute_instance.machine_type"; got != want {
+		t.Fatalf("got logical location %q, want %q", got, want)
+	}
+	if results[0].Level != "error" {
+		t.Fatalf("got level %q, want error", results[0].Level)
+	}
+}
+
+func TestMarshalSARIF_Empty(t *testing.T) {
+	data, err := MarshalSARIF(nil)
+	if err != nil {
+		t.Fatalf("MarshalSARIF returned an error: %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(data, &log); err != nil {
+		t.Fatalf("MarshalSARIF produced invalid JSON: %v", err)
+	}
+	if len(log.Runs[0].Results) != 0 {
+		t.Fatalf("got %d results for no findings, want 0", len(log.Runs[0].Results))
+	}
+	if len(log.Runs[0].Tool.Driver.Rules) != 0 {
+		t.Fatalf("got %d declared rules for no findings, want 0", len(log.Runs[0].Tool.Driver.Rules))
+	}
+}
@@ -0,0 +1,83 @@
+package report
+
+import (
+	"fmt"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Waiver suppresses a single (identifier, resource, field) finding until
+// Expiry, requiring a Justification so waivers read as a conscious
+// decision rather than a silenced warning.
+type Waiver struct {
+	Identifier    string    `yaml:"identifier"`
+	Resource      string    `yaml:"resource"`
+	Field         string    `yaml:"field"`
+	Justification string    `yaml:"justification"`
+	Expiry        time.Time `yaml:"expiry"`
+}
+
+// ParseWaivers reads a waiver file's contents, formatted as a YAML list
+// of Waiver entries.
+func ParseWaivers(data []byte) ([]Waiver, error) {
+	var waivers []Waiver
+	if err := yaml.Unmarshal(data, &waivers); err != nil {
+		return nil, fmt.Errorf("parsing waiver file: %w", err)
+	}
+	for _, waiver := range waivers {
+		if waiver.Justification == "" {
+			return nil, fmt.Errorf("waiver for %s/%s/%s is missing a justification", waiver.Identifier, waiver.Resource, waiver.Field)
+		}
+		if waiver.Expiry.IsZero() {
+			return nil, fmt.Errorf("waiver for %s/%s/%s is missing an expiry", waiver.Identifier, waiver.Resource, waiver.Field)
+		}
+	}
+	return waivers, nil
+}
+
+// ApplyWaivers filters findings through waivers, dropping any finding
+// covered by an unexpired waiver. It also returns a warning for every
+// waiver that's expired or never matched a finding, so stale waivers get
+// surfaced instead of silently rotting.
+func ApplyWaivers(findings []Finding, waivers []Waiver, now time.Time) ([]Finding, []string) {
+	used := make([]bool, len(waivers))
+	remaining := []Finding{}
+
+	for _, finding := range findings {
+		waived := false
+		for i, waiver := range waivers {
+			if !waiverCovers(waiver, finding) {
+				continue
+			}
+			if waiver.Expiry.Before(now) {
+				continue
+			}
+			used[i] = true
+			waived = true
+			break
+		}
+		if !waived {
+			remaining = append(remaining, finding)
+		}
+	}
+
+	warnings := []string{}
+	for i, waiver := range waivers {
+		if waiver.Expiry.Before(now) {
+			warnings = append(warnings, fmt.Sprintf("waiver for %s/%s/%s expired on %s", waiver.Identifier, waiver.Resource, waiver.Field, waiver.Expiry.Format("2006-01-02")))
+			continue
+		}
+		if !used[i] {
+			warnings = append(warnings, fmt.Sprintf("waiver for %s/%s/%s is unused", waiver.Identifier, waiver.Resource, waiver.Field))
+		}
+	}
+
+	return remaining, warnings
+}
+
+func waiverCovers(waiver Waiver, finding Finding) bool {
+	return waiver.Identifier == finding.RuleIdentifier &&
+		waiver.Resource == finding.Resource &&
+		waiver.Field == finding.Field
+}
@@ -0,0 +1,115 @@
+package report
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseWaivers(t *testing.T) {
+	cases := []struct {
+		name    string
+		yaml    string
+		wantErr bool
+	}{
+		{
+			name: "valid waiver",
+			yaml: `
+- identifier: resource-schema-field-force-new
+  resource: google_compute_instance
+  field: machine_type
+  justification: tracked in b/12345, migrating consumers by Q3
+  expiry: 2099-01-01T00:00:00Z
+`,
+		},
+		{
+			name: "missing justification",
+			yaml: `
+- identifier: resource-schema-field-force-new
+  resource: google_compute_instance
+  field: machine_type
+  expiry: 2099-01-01T00:00:00Z
+`,
+			wantErr: true,
+		},
+		{
+			name: "missing expiry",
+			yaml: `
+- identifier: resource-schema-field-force-new
+  resource: google_compute_instance
+  field: machine_type
+  justification: tracked in b/12345
+`,
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := ParseWaivers([]byte(c.yaml))
+			if c.wantErr && err == nil {
+				t.Fatalf("expected an error, got nil")
+			}
+			if !c.wantErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestApplyWaivers(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	findings := []Finding{
+		{RuleIdentifier: "resource-schema-field-force-new", Resource: "google_compute_instance", Field: "machine_type"},
+		{RuleIdentifier: "resource-schema-field-sensitive-removed", Resource: "google_sql_user", Field: "password"},
+	}
+
+	waivers := []Waiver{
+		{
+			Identifier:    "resource-schema-field-force-new",
+			Resource:      "google_compute_instance",
+			Field:         "machine_type",
+			Justification: "tracked in b/12345",
+			Expiry:        now.Add(24 * time.Hour),
+		},
+		{
+			Identifier:    "resource-schema-field-sensitive-removed",
+			Resource:      "google_sql_user",
+			Field:         "password",
+			Justification: "expired before anyone acted on it",
+			Expiry:        now.Add(-24 * time.Hour),
+		},
+		{
+			Identifier:    "resource-schema-field-type-change",
+			Resource:      "google_unrelated_resource",
+			Field:         "unused",
+			Justification: "never matched by any finding",
+			Expiry:        now.Add(24 * time.Hour),
+		},
+	}
+
+	remaining, warnings := ApplyWaivers(findings, waivers, now)
+
+	if len(remaining) != 1 || remaining[0].Field != "password" {
+		t.Fatalf("expected only the expired-waiver finding to remain, got %+v", remaining)
+	}
+
+	if len(warnings) != 2 {
+		t.Fatalf("got %d warnings, want 2 (one expired, one unused), got %v", len(warnings), warnings)
+	}
+}
+
+func TestApplyWaivers_NoMatch(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	findings := []Finding{
+		{RuleIdentifier: "resource-schema-field-force-new", Resource: "google_compute_instance", Field: "machine_type"},
+	}
+
+	remaining, warnings := ApplyWaivers(findings, nil, now)
+	if len(remaining) != 1 {
+		t.Fatalf("expected the finding to remain with no waivers, got %+v", remaining)
+	}
+	if len(warnings) != 0 {
+		t.Fatalf("expected no warnings with no waivers, got %v", warnings)
+	}
+}
@@ -10,17 +10,47 @@ import (
 // ResourceSchemaRule provides structure for
 // rules regarding resource attribute changes
 type ResourceSchemaRule struct {
-	name        string
-	definition  string
-	message     string
-	identifier  string
-	isRuleBreak func(old, new map[string]*schema.Schema) []string
+	name       string
+	definition string
+	message    string
+	identifier string
+	// migrationAware marks rules whose breakage can be carried out safely
+	// through a schema.Resource's SchemaVersion/StateUpgraders. Such rules
+	// report no fields when the upgrader chain covers the change; there is
+	// no separate "migrated" result. Rules that are not migration-aware
+	// (e.g. adding ForceNew) always fire regardless of state upgraders.
+	migrationAware bool
+	isRuleBreak    func(old, new *schema.Resource) []string
 }
 
 // ResourceSchemaRules is a list of ResourceInventoryRule
 // guarding against provider breaking changes
-var ResourceSchemaRules = []ResourceSchemaRule{resourceSchemaRule_RemovingAField, resourceSchemaRule_ChangingResourceIDFormat, resourceSchemaRule_ChangingImportIDFormat}
+var ResourceSchemaRules = []ResourceSchemaRule{
+	resourceSchemaRule_RemovingAField,
+	resourceSchemaRule_ChangingResourceIDFormat,
+	resourceSchemaRule_ChangingImportIDFormat,
+	resourceSchemaRule_AddingForceNew,
+	resourceSchemaRule_ChangingFieldType,
+	resourceSchemaRule_OptionalOrComputedRemoved,
+	resourceSchemaRule_SensitiveRemoved,
+	resourceSchemaRule_NarrowingCollectionBounds,
+}
 
+// resourceSchemaRule_ChangingResourceIDFormat and
+// resourceSchemaRule_ChangingImportIDFormat are NOT implemented by this
+// package and remain Undetectable() (no isRuleBreak), unchanged from
+// before this rule set existed. daveavi/magic-modules#chunk0-5 asked for
+// these to become first-class automated checks by diffing each
+// resource's ID/import-format template strings across versions, but
+// *schema.Resource doesn't carry that data: Importer only exposes
+// StateContextFunc/StateFunc closures, and a template string can't be
+// recovered from a compiled function value. The templates live in
+// generator metadata (e.g. id_format/import_format config) outside this
+// package, so detecting this rule needs that metadata threaded in as a
+// new comparator input - this package alone can't do it. Leaving these
+// two rules Undetectable() is the honest state of chunk0-5: it is not
+// done, not a partial implementation, and requires a producer for
+// id_format/import_format data before it can be picked back up.
 var resourceSchemaRule_ChangingResourceIDFormat = ResourceSchemaRule{
 	name:       "Changing resource ID format",
 	definition: "Terraform uses resource ID to read resource state from the api. Modification of the ID format will break the ability to parse the IDs from any deployments.",
@@ -34,24 +64,268 @@ var resourceSchemaRule_ChangingImportIDFormat = ResourceSchemaRule{
 }
 
 var resourceSchemaRule_RemovingAField = ResourceSchemaRule{
-	name:        "Removing or Renaming an field",
-	definition:  "In terraform fields should be retained whenever possible. A removable of an field will result in a configuration breakage wherever a dependency on that field exists. Renaming or Removing a field are functionally equivalent in terms of configuration breakages.",
-	message:     "Field {{field}} within resource {{resource}} was either removed or renamed",
-	identifier:  "resource-schema-field-removal-or-rename",
-	isRuleBreak: resourceSchemaRule_RemovingAField_func,
+	name:           "Removing or Renaming an field",
+	definition:     "In terraform fields should be retained whenever possible. A removable of an field will result in a configuration breakage wherever a dependency on that field exists. Renaming or Removing a field are functionally equivalent in terms of configuration breakages.",
+	message:        "Field {{field}} within resource {{resource}} was either removed or renamed",
+	identifier:     "resource-schema-field-removal-or-rename",
+	migrationAware: true,
+	isRuleBreak:    resourceSchemaRule_RemovingAField_func,
 }
 
-func resourceSchemaRule_RemovingAField_func(old, new map[string]*schema.Schema) []string {
+func resourceSchemaRule_RemovingAField_func(old, new *schema.Resource) []string {
+	if old == nil || new == nil {
+		return nil
+	}
+	if isMigrationCovered(old, new) {
+		return nil
+	}
 	keysNotPresent := []string{}
-	for key := range old {
-		_, exists := new[key]
-		if !exists {
-			keysNotPresent = append(keysNotPresent, key)
+	for _, field := range resourceSchemaFields(old.Schema, new.Schema) {
+		if field.new != nil {
+			continue
 		}
+		// A field that was deprecated in the previous released version is
+		// allowed to be removed now; it already went through a deprecation
+		// cycle and consumers had a release to migrate off of it.
+		if field.old != nil && field.old.Deprecated != "" {
+			continue
+		}
+		keysNotPresent = append(keysNotPresent, field.path)
 	}
 	return keysNotPresent
 }
 
+// isMigrationCovered reports whether new bumped SchemaVersion over old and
+// registered a StateUpgraders entry for every version from old's
+// SchemaVersion up to new's SchemaVersion-1, meaning a
+// migration-aware rule's otherwise-breaking finding is handled by the
+// upgrader chain rather than left to break existing state.
+func isMigrationCovered(old, new *schema.Resource) bool {
+	if old == nil || new == nil {
+		return false
+	}
+	if new.SchemaVersion <= old.SchemaVersion {
+		return false
+	}
+	coveredFrom := map[int]bool{}
+	for _, upgrader := range new.StateUpgraders {
+		coveredFrom[upgrader.Version] = true
+	}
+	// Every hop from old.SchemaVersion up to new.SchemaVersion-1 needs its
+	// own upgrader; a gap anywhere in the chain leaves state from that
+	// version unmigrated even though the resource now reports a newer
+	// SchemaVersion.
+	for v := old.SchemaVersion; v < new.SchemaVersion; v++ {
+		if !coveredFrom[v] {
+			return false
+		}
+	}
+	return true
+}
+
+// schemaFieldPair associates an old/new pair of *schema.Schema with the
+// dotted field path they were found at. Either old or new may be nil,
+// indicating the field was added or removed respectively.
+type schemaFieldPair struct {
+	path string
+	old  *schema.Schema
+	new  *schema.Schema
+}
+
+// resourceSchemaFields is the shared traversal every field-level rule
+// should build on. It walks old and new side by side, recursing into
+// nested block schemas (TypeList/TypeSet whose Elem is a *schema.Resource)
+// so that rules see fully qualified paths, e.g.
+// "network_interface.0.access_config.0.public_ip", instead of only the
+// top-level field names.
+func resourceSchemaFields(old, new map[string]*schema.Schema) []schemaFieldPair {
+	return walkResourceSchemaFields("", old, new)
+}
+
+func walkResourceSchemaFields(prefix string, old, new map[string]*schema.Schema) []schemaFieldPair {
+	fields := []schemaFieldPair{}
+	seen := map[string]bool{}
+
+	for key, oldField := range old {
+		seen[key] = true
+		path := fieldPath(prefix, key)
+		newField := new[key]
+		fields = append(fields, schemaFieldPair{path: path, old: oldField, new: newField})
+		fields = append(fields, walkNestedSchemaFields(path, oldField, newField)...)
+	}
+
+	for key, newField := range new {
+		if seen[key] {
+			continue
+		}
+		fields = append(fields, schemaFieldPair{path: fieldPath(prefix, key), old: nil, new: newField})
+	}
+
+	return fields
+}
+
+// walkNestedSchemaFields descends into a block's nested *schema.Resource,
+// if either side of the field has one, and recurses using the shared
+// traversal above. Set blocks are walked the same way as list blocks:
+// we don't have instance data to key sets by, so nested fields are
+// compared positionally, which is what matters for schema-level breakage.
+func walkNestedSchemaFields(path string, old, new *schema.Schema) []schemaFieldPair {
+	oldNested, oldIsBlock := nestedBlockSchema(old)
+	newNested, newIsBlock := nestedBlockSchema(new)
+	if !oldIsBlock && !newIsBlock {
+		return nil
+	}
+	return walkResourceSchemaFields(path+".0", oldNested, newNested)
+}
+
+// nestedBlockSchema returns the field map of a nested *schema.Resource
+// block, if the schema's Elem is one. A bare *schema.Schema Elem (e.g. a
+// TypeList of TypeString) has no sub-fields to recurse into.
+func nestedBlockSchema(s *schema.Schema) (map[string]*schema.Schema, bool) {
+	if s == nil {
+		return nil, false
+	}
+	if resource, ok := s.Elem.(*schema.Resource); ok {
+		return resource.Schema, true
+	}
+	return nil, false
+}
+
+func fieldPath(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+var resourceSchemaRule_AddingForceNew = ResourceSchemaRule{
+	name:        "Adding ForceNew to a field",
+	definition:  "Marking a previously updatable field as ForceNew causes terraform to destroy and recreate the resource on the next apply instead of updating it in place, which is a breaking change for any existing deployment.",
+	message:     "Field {{field}} within resource {{resource}} gained ForceNew",
+	identifier:  "resource-schema-field-force-new",
+	isRuleBreak: resourceSchemaRule_AddingForceNew_func,
+}
+
+func resourceSchemaRule_AddingForceNew_func(old, new *schema.Resource) []string {
+	if old == nil || new == nil {
+		return nil
+	}
+	fieldsBroken := []string{}
+	for _, field := range resourceSchemaFields(old.Schema, new.Schema) {
+		if field.old == nil || field.new == nil {
+			continue
+		}
+		if !field.old.ForceNew && field.new.ForceNew {
+			fieldsBroken = append(fieldsBroken, field.path)
+		}
+	}
+	return fieldsBroken
+}
+
+var resourceSchemaRule_ChangingFieldType = ResourceSchemaRule{
+	name:           "Changing an field's type",
+	definition:     "Changing the type of an existing field will fail to parse any configuration or state written against the previous type, breaking existing deployments.",
+	message:        "Field {{field}} within resource {{resource}} changed type",
+	identifier:     "resource-schema-field-type-change",
+	migrationAware: true,
+	isRuleBreak:    resourceSchemaRule_ChangingFieldType_func,
+}
+
+func resourceSchemaRule_ChangingFieldType_func(old, new *schema.Resource) []string {
+	if old == nil || new == nil {
+		return nil
+	}
+	if isMigrationCovered(old, new) {
+		return nil
+	}
+	fieldsBroken := []string{}
+	for _, field := range resourceSchemaFields(old.Schema, new.Schema) {
+		if field.old == nil || field.new == nil {
+			continue
+		}
+		if field.old.Type != field.new.Type {
+			fieldsBroken = append(fieldsBroken, field.path)
+		}
+	}
+	return fieldsBroken
+}
+
+var resourceSchemaRule_OptionalOrComputedRemoved = ResourceSchemaRule{
+	name:        "Removing Optional or Computed from a field",
+	definition:  "Fields should not transition from Optional to Required, nor stop being Computed, as existing configurations that relied on omitting the field or reading back a computed value will fail to apply.",
+	message:     "Field {{field}} within resource {{resource}} became Required and/or stopped being Computed",
+	identifier:  "resource-schema-field-optional-required",
+	isRuleBreak: resourceSchemaRule_OptionalOrComputedRemoved_func,
+}
+
+func resourceSchemaRule_OptionalOrComputedRemoved_func(old, new *schema.Resource) []string {
+	if old == nil || new == nil {
+		return nil
+	}
+	fieldsBroken := []string{}
+	for _, field := range resourceSchemaFields(old.Schema, new.Schema) {
+		if field.old == nil || field.new == nil {
+			continue
+		}
+		becameRequired := field.old.Optional && field.new.Required
+		stoppedComputed := field.old.Computed && !field.new.Computed
+		if becameRequired || stoppedComputed {
+			fieldsBroken = append(fieldsBroken, field.path)
+		}
+	}
+	return fieldsBroken
+}
+
+var resourceSchemaRule_SensitiveRemoved = ResourceSchemaRule{
+	name:        "Removing Sensitive from a field",
+	definition:  "A field marked Sensitive has its value redacted from plan/apply output and logs. Removing that marking exposes previously hidden values, which is a state-leak regression for existing deployments.",
+	message:     "Field {{field}} within resource {{resource}} stopped being Sensitive",
+	identifier:  "resource-schema-field-sensitive-removed",
+	isRuleBreak: resourceSchemaRule_SensitiveRemoved_func,
+}
+
+func resourceSchemaRule_SensitiveRemoved_func(old, new *schema.Resource) []string {
+	if old == nil || new == nil {
+		return nil
+	}
+	fieldsBroken := []string{}
+	for _, field := range resourceSchemaFields(old.Schema, new.Schema) {
+		if field.old == nil || field.new == nil {
+			continue
+		}
+		if field.old.Sensitive && !field.new.Sensitive {
+			fieldsBroken = append(fieldsBroken, field.path)
+		}
+	}
+	return fieldsBroken
+}
+
+var resourceSchemaRule_NarrowingCollectionBounds = ResourceSchemaRule{
+	name:        "Narrowing a collection field's bounds",
+	definition:  "Decreasing MaxItems or increasing MinItems on a list or set field rejects configurations that were previously valid, breaking existing deployments that rely on the prior bounds.",
+	message:     "Field {{field}} within resource {{resource}} narrowed its MaxItems/MinItems bounds",
+	identifier:  "resource-schema-field-collection-bounds",
+	isRuleBreak: resourceSchemaRule_NarrowingCollectionBounds_func,
+}
+
+func resourceSchemaRule_NarrowingCollectionBounds_func(old, new *schema.Resource) []string {
+	if old == nil || new == nil {
+		return nil
+	}
+	fieldsBroken := []string{}
+	for _, field := range resourceSchemaFields(old.Schema, new.Schema) {
+		if field.old == nil || field.new == nil {
+			continue
+		}
+		maxItemsNarrowed := field.new.MaxItems != 0 && (field.old.MaxItems == 0 || field.new.MaxItems < field.old.MaxItems)
+		minItemsNarrowed := field.new.MinItems > field.old.MinItems
+		if maxItemsNarrowed || minItemsNarrowed {
+			fieldsBroken = append(fieldsBroken, field.path)
+		}
+	}
+	return fieldsBroken
+}
+
 func resourceSchemaRulesToRuleArray(rss []ResourceSchemaRule) []Rule {
 	var rules []Rule
 	for _, rs := range rss {
@@ -75,6 +349,14 @@ func (rs ResourceSchemaRule) Identifier() string {
 	return rs.identifier
 }
 
+// MigrationAware - informs whether this rule reports no fields when the
+// resource's SchemaVersion/StateUpgraders cover the change; such changes
+// are dropped, not reported under a separate "migrated" result. Rules
+// that are not migration-aware always fire.
+func (rs ResourceSchemaRule) MigrationAware() bool {
+	return rs.migrationAware
+}
+
 // Message - a message to to inform the user
 // of a breakage.
 func (rs ResourceSchemaRule) Message(version, resource, field string) string {
@@ -86,9 +368,11 @@ func (rs ResourceSchemaRule) Message(version, resource, field string) string {
 	return msg + documentationReference(version, rs.identifier)
 }
 
-// IsRuleBreak - compares the field entries and returns
-// a list of fields violating the rule
-func (rs ResourceSchemaRule) IsRuleBreak(old, new map[string]*schema.Schema) []string {
+// IsRuleBreak - compares the resource's old and new schema and returns a
+// list of fields violating the rule. old and new are the whole
+// *schema.Resource, not just their Schema maps, so migration-aware rules
+// can consult SchemaVersion/StateUpgraders before reporting.
+func (rs ResourceSchemaRule) IsRuleBreak(old, new *schema.Resource) []string {
 	if rs.isRuleBreak == nil {
 		return []string{}
 	}
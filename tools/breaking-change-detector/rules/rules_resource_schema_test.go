@@ -0,0 +1,283 @@
+package rules
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func paths(fields []schemaFieldPair) []string {
+	out := make([]string, 0, len(fields))
+	for _, field := range fields {
+		out = append(out, field.path)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func TestResourceSchemaFields_TopLevel(t *testing.T) {
+	cases := []struct {
+		name     string
+		old, new map[string]*schema.Schema
+		want     []string
+	}{
+		{
+			name: "unchanged field",
+			old:  map[string]*schema.Schema{"foo": {Type: schema.TypeString}},
+			new:  map[string]*schema.Schema{"foo": {Type: schema.TypeString}},
+			want: []string{"foo"},
+		},
+		{
+			name: "field removed",
+			old:  map[string]*schema.Schema{"foo": {Type: schema.TypeString}, "bar": {Type: schema.TypeString}},
+			new:  map[string]*schema.Schema{"foo": {Type: schema.TypeString}},
+			want: []string{"bar", "foo"},
+		},
+		{
+			name: "field added",
+			old:  map[string]*schema.Schema{"foo": {Type: schema.TypeString}},
+			new:  map[string]*schema.Schema{"foo": {Type: schema.TypeString}, "baz": {Type: schema.TypeString}},
+			want: []string{"baz", "foo"},
+		},
+		{
+			name: "field renamed is a remove plus an add",
+			old:  map[string]*schema.Schema{"foo": {Type: schema.TypeString}},
+			new:  map[string]*schema.Schema{"foo_renamed": {Type: schema.TypeString}},
+			want: []string{"foo", "foo_renamed"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := paths(resourceSchemaFields(c.old, c.new))
+			if len(got) != len(c.want) {
+				t.Fatalf("got paths %v, want %v", got, c.want)
+			}
+			for i := range got {
+				if got[i] != c.want[i] {
+					t.Fatalf("got paths %v, want %v", got, c.want)
+				}
+			}
+		})
+	}
+}
+
+func TestResourceSchemaFields_RemovalAndAddition(t *testing.T) {
+	old := map[string]*schema.Schema{"foo": {Type: schema.TypeString}, "bar": {Type: schema.TypeString}}
+	new := map[string]*schema.Schema{"foo": {Type: schema.TypeString}, "baz": {Type: schema.TypeString}}
+
+	var removed, added []string
+	for _, field := range resourceSchemaFields(old, new) {
+		if field.new == nil {
+			removed = append(removed, field.path)
+		}
+		if field.old == nil {
+			added = append(added, field.path)
+		}
+	}
+
+	if len(removed) != 1 || removed[0] != "bar" {
+		t.Fatalf("expected bar to be reported removed, got %v", removed)
+	}
+	if len(added) != 1 || added[0] != "baz" {
+		t.Fatalf("expected baz to be reported added, got %v", added)
+	}
+}
+
+func TestResourceSchemaFields_NestedBlockRecursion(t *testing.T) {
+	old := map[string]*schema.Schema{
+		"network_interface": {
+			Type: schema.TypeList,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"access_config": {
+						Type: schema.TypeList,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"public_ip": {Type: schema.TypeString},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	new := map[string]*schema.Schema{
+		"network_interface": {
+			Type: schema.TypeList,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"access_config": {
+						Type: schema.TypeList,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	var removed []string
+	for _, field := range resourceSchemaFields(old, new) {
+		if field.new == nil {
+			removed = append(removed, field.path)
+		}
+	}
+
+	want := "network_interface.0.access_config.0.public_ip"
+	if len(removed) != 1 || removed[0] != want {
+		t.Fatalf("got removed paths %v, want [%s]", removed, want)
+	}
+}
+
+func TestResourceSchemaRule_RemovingAField(t *testing.T) {
+	old := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"foo": {Type: schema.TypeString},
+			"bar": {Type: schema.TypeString, Deprecated: "use foo instead"},
+		},
+	}
+	new := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"foo": {Type: schema.TypeString},
+		},
+	}
+
+	got := resourceSchemaRule_RemovingAField_func(old, new)
+	if len(got) != 0 {
+		t.Fatalf("expected removing a previously-deprecated field to be allowed, got breaks: %v", got)
+	}
+
+	old.Schema["baz"] = &schema.Schema{Type: schema.TypeString}
+	got = resourceSchemaRule_RemovingAField_func(old, new)
+	if len(got) != 1 || got[0] != "baz" {
+		t.Fatalf("expected baz to be reported as a breaking removal, got %v", got)
+	}
+}
+
+func resourceWithField(field *schema.Schema) *schema.Resource {
+	return &schema.Resource{Schema: map[string]*schema.Schema{"foo": field}}
+}
+
+func TestResourceSchemaRule_AddingForceNew(t *testing.T) {
+	cases := []struct {
+		name     string
+		old, new *schema.Schema
+		wantFlag bool
+	}{
+		{"not force new on either side", &schema.Schema{Type: schema.TypeString}, &schema.Schema{Type: schema.TypeString}, false},
+		{"force new on both sides", &schema.Schema{Type: schema.TypeString, ForceNew: true}, &schema.Schema{Type: schema.TypeString, ForceNew: true}, false},
+		{"force new added", &schema.Schema{Type: schema.TypeString}, &schema.Schema{Type: schema.TypeString, ForceNew: true}, true},
+		{"force new removed", &schema.Schema{Type: schema.TypeString, ForceNew: true}, &schema.Schema{Type: schema.TypeString}, false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := resourceSchemaRule_AddingForceNew_func(resourceWithField(c.old), resourceWithField(c.new))
+			if flagged := len(got) != 0; flagged != c.wantFlag {
+				t.Fatalf("got breaks %v, want flagged=%v", got, c.wantFlag)
+			}
+		})
+	}
+}
+
+func TestResourceSchemaRule_ChangingFieldType(t *testing.T) {
+	cases := []struct {
+		name     string
+		old, new *schema.Schema
+		wantFlag bool
+	}{
+		{"unchanged type", &schema.Schema{Type: schema.TypeString}, &schema.Schema{Type: schema.TypeString}, false},
+		{"string to int", &schema.Schema{Type: schema.TypeString}, &schema.Schema{Type: schema.TypeInt}, true},
+		{"list to set", &schema.Schema{Type: schema.TypeList}, &schema.Schema{Type: schema.TypeSet}, true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := resourceSchemaRule_ChangingFieldType_func(resourceWithField(c.old), resourceWithField(c.new))
+			if flagged := len(got) != 0; flagged != c.wantFlag {
+				t.Fatalf("got breaks %v, want flagged=%v", got, c.wantFlag)
+			}
+		})
+	}
+
+	old := resourceWithField(&schema.Schema{Type: schema.TypeString})
+	new := &schema.Resource{
+		SchemaVersion: 1,
+		Schema:        map[string]*schema.Schema{"foo": {Type: schema.TypeInt}},
+		StateUpgraders: []schema.StateUpgrader{
+			{Version: 0},
+		},
+	}
+	if got := resourceSchemaRule_ChangingFieldType_func(old, new); len(got) != 0 {
+		t.Fatalf("expected a covered state upgrade to suppress the break, got %v", got)
+	}
+}
+
+func TestResourceSchemaRule_OptionalOrComputedRemoved(t *testing.T) {
+	cases := []struct {
+		name     string
+		old, new *schema.Schema
+		wantFlag bool
+	}{
+		{"stays optional", &schema.Schema{Type: schema.TypeString, Optional: true}, &schema.Schema{Type: schema.TypeString, Optional: true}, false},
+		{"optional becomes required", &schema.Schema{Type: schema.TypeString, Optional: true}, &schema.Schema{Type: schema.TypeString, Required: true}, true},
+		{"stays computed", &schema.Schema{Type: schema.TypeString, Computed: true}, &schema.Schema{Type: schema.TypeString, Computed: true}, false},
+		{"computed removed", &schema.Schema{Type: schema.TypeString, Computed: true}, &schema.Schema{Type: schema.TypeString}, true},
+		{"optional added to computed", &schema.Schema{Type: schema.TypeString, Computed: true}, &schema.Schema{Type: schema.TypeString, Computed: true, Optional: true}, false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := resourceSchemaRule_OptionalOrComputedRemoved_func(resourceWithField(c.old), resourceWithField(c.new))
+			if flagged := len(got) != 0; flagged != c.wantFlag {
+				t.Fatalf("got breaks %v, want flagged=%v", got, c.wantFlag)
+			}
+		})
+	}
+}
+
+func TestResourceSchemaRule_SensitiveRemoved(t *testing.T) {
+	cases := []struct {
+		name     string
+		old, new *schema.Schema
+		wantFlag bool
+	}{
+		{"stays sensitive", &schema.Schema{Type: schema.TypeString, Sensitive: true}, &schema.Schema{Type: schema.TypeString, Sensitive: true}, false},
+		{"sensitive added", &schema.Schema{Type: schema.TypeString}, &schema.Schema{Type: schema.TypeString, Sensitive: true}, false},
+		{"sensitive removed", &schema.Schema{Type: schema.TypeString, Sensitive: true}, &schema.Schema{Type: schema.TypeString}, true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := resourceSchemaRule_SensitiveRemoved_func(resourceWithField(c.old), resourceWithField(c.new))
+			if flagged := len(got) != 0; flagged != c.wantFlag {
+				t.Fatalf("got breaks %v, want flagged=%v", got, c.wantFlag)
+			}
+		})
+	}
+}
+
+func TestResourceSchemaRule_NarrowingCollectionBounds(t *testing.T) {
+	cases := []struct {
+		name     string
+		old, new *schema.Schema
+		wantFlag bool
+	}{
+		{"unbounded on both sides", &schema.Schema{Type: schema.TypeList}, &schema.Schema{Type: schema.TypeList}, false},
+		{"max items decreased", &schema.Schema{Type: schema.TypeList, MaxItems: 5}, &schema.Schema{Type: schema.TypeList, MaxItems: 3}, true},
+		{"max items increased", &schema.Schema{Type: schema.TypeList, MaxItems: 3}, &schema.Schema{Type: schema.TypeList, MaxItems: 5}, false},
+		{"max items unchanged", &schema.Schema{Type: schema.TypeList, MaxItems: 5}, &schema.Schema{Type: schema.TypeList, MaxItems: 5}, false},
+		{"max items newly set narrows from unbounded", &schema.Schema{Type: schema.TypeList}, &schema.Schema{Type: schema.TypeList, MaxItems: 5}, true},
+		{"max items cleared to unbounded", &schema.Schema{Type: schema.TypeList, MaxItems: 5}, &schema.Schema{Type: schema.TypeList}, false},
+		{"min items increased", &schema.Schema{Type: schema.TypeList, MinItems: 1}, &schema.Schema{Type: schema.TypeList, MinItems: 2}, true},
+		{"min items decreased", &schema.Schema{Type: schema.TypeList, MinItems: 2}, &schema.Schema{Type: schema.TypeList, MinItems: 1}, false},
+		{"min items unchanged", &schema.Schema{Type: schema.TypeList, MinItems: 1}, &schema.Schema{Type: schema.TypeList, MinItems: 1}, false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := resourceSchemaRule_NarrowingCollectionBounds_func(resourceWithField(c.old), resourceWithField(c.new))
+			if flagged := len(got) != 0; flagged != c.wantFlag {
+				t.Fatalf("got breaks %v, want flagged=%v", got, c.wantFlag)
+			}
+		})
+	}
+}